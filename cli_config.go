@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// loadConfig reads provider chains and notifier endpoints from an optional
+// YAML/TOML config file. An empty path yields an unconfigured viper.Viper so
+// callers can still fall back to flag defaults and environment variables.
+func loadConfig(path string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetEnvPrefix("PUBLIC_IP")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+
+	if path == "" {
+		return v, nil
+	}
+
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// bindFlags fills in any flag left at its default from the config/env
+// values in v, without overriding flags the user explicitly passed.
+func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
+	var firstErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if firstErr != nil || f.Changed || !v.IsSet(f.Name) {
+			return
+		}
+		if f.Value.Type() == "stringSlice" {
+			firstErr = cmd.Flags().Set(f.Name, strings.Join(v.GetStringSlice(f.Name), ","))
+			return
+		}
+		firstErr = cmd.Flags().Set(f.Name, v.GetString(f.Name))
+	})
+	return firstErr
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// newGetCmd resolves the public IP once and writes it to --output.
+func newGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Resolve the public IP once and write it to the output file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+
+			ipGetter, err := buildIPGetter(flags)
+			if err != nil {
+				return err
+			}
+			encoder, err := buildEncoder(flags)
+			if err != nil {
+				return err
+			}
+			fileWriter, err := buildFileWriter(flags)
+			if err != nil {
+				return err
+			}
+			output, err := flags.GetString("output")
+			if err != nil {
+				return err
+			}
+
+			return Execute(ipGetter, fileWriter, encoder, output)
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/knlambert/unit-tests-demo/internal"
+	"github.com/spf13/pflag"
+)
+
+// buildNotifier maps the --notifier flag (and its endpoint flags) to a
+// Notifier.
+func buildNotifier(flags *pflag.FlagSet) (Notifier, error) {
+	name, err := flags.GetString("notifier")
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "", "stdout":
+		return &internal.StdoutNotifier{}, nil
+	case "webhook":
+		url, err := flags.GetString("webhook-url")
+		if err != nil {
+			return nil, err
+		}
+		if url == "" {
+			return nil, fmt.Errorf("--webhook-url is required for the webhook notifier")
+		}
+		return internal.NewWebhookNotifier(url), nil
+	case "telegram":
+		botToken, err := flags.GetString("telegram-bot-token")
+		if err != nil {
+			return nil, err
+		}
+		chatID, err := flags.GetString("telegram-chat-id")
+		if err != nil {
+			return nil, err
+		}
+		if botToken == "" || chatID == "" {
+			return nil, fmt.Errorf("--telegram-bot-token and --telegram-chat-id are required for the telegram notifier")
+		}
+		return internal.NewTelegramNotifier(botToken, chatID), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", name)
+	}
+}
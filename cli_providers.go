@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/knlambert/unit-tests-demo/internal"
+	"github.com/spf13/pflag"
+)
+
+// buildIPGetter assembles a failover IPGetter from the --provider, --mode
+// and --timeout flags.
+func buildIPGetter(flags *pflag.FlagSet) (IPGetter, error) {
+	names, err := flags.GetStringSlice("provider")
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := flags.GetDuration("timeout")
+	if err != nil {
+		return nil, err
+	}
+	mode, err := buildIPMode(flags)
+	if err != nil {
+		return nil, err
+	}
+
+	client := internal.NewClientConfig(timeout, 0)
+
+	var providers []internal.Provider
+	for _, name := range names {
+		provider, err := buildProvider(name, client)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no provider configured")
+	}
+
+	return internal.NewFailoverGetter(mode, providers...), nil
+}
+
+// buildIPMode maps the --mode flag to an internal.IPMode.
+func buildIPMode(flags *pflag.FlagSet) (internal.IPMode, error) {
+	mode, err := flags.GetString("mode")
+	if err != nil {
+		return internal.IPModeAny, err
+	}
+
+	switch mode {
+	case "", "any":
+		return internal.IPModeAny, nil
+	case "v4":
+		return internal.IPModeV4, nil
+	case "v6":
+		return internal.IPModeV6, nil
+	default:
+		return internal.IPModeAny, fmt.Errorf("unknown mode %q", mode)
+	}
+}
+
+func buildProvider(name string, client internal.ClientConfig) (internal.Provider, error) {
+	switch name {
+	case "ipify":
+		return internal.NewIpify(client), nil
+	case "ifconfigme":
+		return internal.NewIfConfigMe(client), nil
+	case "icanhazip":
+		return internal.NewIcanHazIP(client), nil
+	case "opendns":
+		return internal.NewOpenDNS(client), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+}
+
+// buildFileWriter builds the FileWriter used by get/watch, honoring the
+// --rotate flag.
+func buildFileWriter(flags *pflag.FlagSet) (FileWriter, error) {
+	rotate, err := flags.GetInt("rotate")
+	if err != nil {
+		return nil, err
+	}
+	return internal.NewFileRepository(rotate), nil
+}
+
+// buildEncoder maps the --format flag to an Encoder.
+func buildEncoder(flags *pflag.FlagSet) (Encoder, error) {
+	format, err := flags.GetString("format")
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "", "plain":
+		return internal.PlainEncoder{}, nil
+	case "json":
+		return internal.JSONEncoder{}, nil
+	case "ndjson":
+		return internal.JSONEncoder{NDJSON: true}, nil
+	case "csv":
+		return internal.CSVEncoder{}, nil
+	case "prometheus":
+		return internal.PrometheusEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
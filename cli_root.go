@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/knlambert/unit-tests-demo/internal"
+	"github.com/spf13/cobra"
+)
+
+var cfgFile string
+
+// newRootCmd builds the CLI's command tree: get, watch and version, sharing
+// the --provider/--output/--format/--mode/--rotate/--timeout/--config flags.
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:          "unit-tests-demo",
+		Short:        "Resolve and track your public IP address",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			v, err := loadConfig(cfgFile)
+			if err != nil {
+				return err
+			}
+			return bindFlags(cmd, v)
+		},
+	}
+
+	root.PersistentFlags().StringVar(&cfgFile, "config", "", "path to a YAML/TOML config file declaring providers and notifiers")
+	root.PersistentFlags().StringSlice("provider", []string{"ipify"}, "providers to query, in failover order (ipify, ifconfigme, icanhazip, opendns)")
+	root.PersistentFlags().String("output", "output.txt", "file the resolved IP is written to")
+	root.PersistentFlags().String("format", "plain", "output format: plain, json, ndjson, csv, prometheus")
+	root.PersistentFlags().String("mode", "any", "IP family to resolve: any, v4, v6")
+	root.PersistentFlags().Int("rotate", 0, "keep this many previous versions of the output file (output.txt.1, .2, ...)")
+	root.PersistentFlags().Duration("timeout", internal.DefaultTimeout, "per-request HTTP timeout")
+
+	root.AddCommand(newGetCmd(), newWatchCmd(), newVersionCmd())
+	return root
+}
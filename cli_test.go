@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootCmd_CommandTree(t *testing.T) {
+	root := newRootCmd()
+
+	names := make([]string, 0, len(root.Commands()))
+	for _, cmd := range root.Commands() {
+		names = append(names, cmd.Name())
+	}
+
+	assert.ElementsMatch(t, []string{"get", "watch", "version"}, names)
+}
+
+func TestVersionCmd(t *testing.T) {
+	root := newRootCmd()
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs([]string{"version"})
+
+	err := root.Execute()
+
+	assert.NoError(t, err)
+	assert.Equal(t, version+"\n", out.String())
+}
+
+func TestGetCmd_UnknownProvider(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"get", "--provider", "bogus"})
+
+	err := root.Execute()
+
+	assert.ErrorContains(t, err, `unknown provider "bogus"`)
+}
+
+func TestGetCmd_UnknownFormat(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"get", "--format", "bogus"})
+
+	err := root.Execute()
+
+	assert.ErrorContains(t, err, `unknown format "bogus"`)
+}
+
+func TestGetCmd_UnknownMode(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"get", "--mode", "bogus"})
+
+	err := root.Execute()
+
+	assert.ErrorContains(t, err, `unknown mode "bogus"`)
+}
+
+func TestWatchCmd_UnknownNotifier(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"watch", "--notifier", "bogus"})
+
+	err := root.Execute()
+
+	assert.ErrorContains(t, err, `unknown notifier "bogus"`)
+}
+
+func TestWatchCmd_WebhookNotifierRequiresURL(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"watch", "--notifier", "webhook"})
+
+	err := root.Execute()
+
+	assert.ErrorContains(t, err, "--webhook-url is required")
+}
+
+func TestWatchCmd_TelegramNotifierRequiresCredentials(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"watch", "--notifier", "telegram"})
+
+	err := root.Execute()
+
+	assert.ErrorContains(t, err, "--telegram-bot-token and --telegram-chat-id are required")
+}
+
+func TestBindFlags_LoadsProviderListFromConfigFile(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(cfgPath, []byte("provider:\n  - ifconfigme\n  - icanhazip\n"), 0644)
+	assert.NoError(t, err)
+
+	v, err := loadConfig(cfgPath)
+	assert.NoError(t, err)
+
+	root := newRootCmd()
+	getCmd, _, err := root.Find([]string{"get"})
+	assert.NoError(t, err)
+	assert.NoError(t, getCmd.ParseFlags(nil))
+
+	err = bindFlags(getCmd, v)
+	assert.NoError(t, err)
+
+	providers, err := getCmd.Flags().GetStringSlice("provider")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ifconfigme", "icanhazip"}, providers)
+}
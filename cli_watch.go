@@ -0,0 +1,66 @@
+package main
+
+import (
+	"time"
+
+	"github.com/knlambert/unit-tests-demo/internal"
+	"github.com/spf13/cobra"
+)
+
+// newWatchCmd continuously polls the public IP and notifies on change.
+func newWatchCmd() *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Continuously poll the public IP and notify on change",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+
+			ipGetter, err := buildIPGetter(flags)
+			if err != nil {
+				return err
+			}
+			encoder, err := buildEncoder(flags)
+			if err != nil {
+				return err
+			}
+			fileWriter, err := buildFileWriter(flags)
+			if err != nil {
+				return err
+			}
+			notifier, err := buildNotifier(flags)
+			if err != nil {
+				return err
+			}
+			output, err := flags.GetString("output")
+			if err != nil {
+				return err
+			}
+			history, err := flags.GetString("history")
+			if err != nil {
+				return err
+			}
+
+			return Watch(
+				cmd.Context(),
+				ipGetter,
+				fileWriter,
+				encoder,
+				notifier,
+				output,
+				history,
+				interval,
+				internal.SystemClock{},
+			)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "polling interval")
+	cmd.Flags().String("history", "", "optional file to append a timestamped IP history log to")
+	cmd.Flags().String("notifier", "stdout", "notifier to dispatch IP changes to: stdout, webhook, telegram")
+	cmd.Flags().String("webhook-url", "", "URL the webhook notifier POSTs to")
+	cmd.Flags().String("telegram-bot-token", "", "bot token for the telegram notifier")
+	cmd.Flags().String("telegram-chat-id", "", "chat id for the telegram notifier")
+	return cmd
+}
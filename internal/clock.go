@@ -0,0 +1,10 @@
+package internal
+
+import "time"
+
+// SystemClock is the real-time implementation of main.Clock.
+type SystemClock struct{}
+
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
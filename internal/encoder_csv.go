@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+)
+
+// CSVEncoder encodes a Record as a single CSV row:
+// ip,timestamp,provider,latency_ms
+type CSVEncoder struct{}
+
+func (CSVEncoder) Encode(record Record) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	row := []string{
+		record.IP.String(),
+		record.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		record.Provider,
+		strconv.FormatFloat(float64(record.Latency.Microseconds())/1000, 'f', -1, 64),
+	}
+	if err := w.Write(row); err != nil {
+		return nil, err
+	}
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,35 @@
+package internal
+
+import "encoding/json"
+
+// jsonRecord is the wire shape written by JSONEncoder.
+type jsonRecord struct {
+	IP        string  `json:"ip"`
+	Timestamp string  `json:"timestamp"`
+	Provider  string  `json:"provider"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// JSONEncoder encodes a Record as a single JSON object. When NDJSON is set,
+// a trailing newline is appended so successive calls can be appended to the
+// same file as newline-delimited JSON.
+type JSONEncoder struct {
+	NDJSON bool
+}
+
+func (e JSONEncoder) Encode(record Record) ([]byte, error) {
+	payload, err := json.Marshal(jsonRecord{
+		IP:        record.IP.String(),
+		Timestamp: record.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		Provider:  record.Provider,
+		LatencyMS: float64(record.Latency.Microseconds()) / 1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if e.NDJSON {
+		payload = append(payload, '\n')
+	}
+	return payload, nil
+}
@@ -0,0 +1,9 @@
+package internal
+
+// PlainEncoder encodes a Record as its bare IP, matching the tool's
+// original output format.
+type PlainEncoder struct{}
+
+func (PlainEncoder) Encode(record Record) ([]byte, error) {
+	return []byte(record.IP.String()), nil
+}
@@ -0,0 +1,18 @@
+package internal
+
+import "fmt"
+
+// PrometheusEncoder encodes a Record in the node_exporter textfile
+// collector format, e.g.:
+//
+//	public_ip_info{ip="1.2.3.4",provider="ipify"} 1
+type PrometheusEncoder struct{}
+
+func (PrometheusEncoder) Encode(record Record) ([]byte, error) {
+	line := fmt.Sprintf(
+		"public_ip_info{ip=%q,provider=%q} 1\n",
+		record.IP.String(),
+		record.Provider,
+	)
+	return []byte(line), nil
+}
@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecord() Record {
+	return Record{
+		IP:        net.ParseIP("184.162.7.66"),
+		Timestamp: time.Date(2026, 7, 25, 12, 0, 0, 0, time.UTC),
+		Provider:  "ipify",
+		Latency:   150 * time.Millisecond,
+	}
+}
+
+func TestPlainEncoder_Encode(t *testing.T) {
+	encoded, err := PlainEncoder{}.Encode(testRecord())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "184.162.7.66", string(encoded))
+}
+
+func TestCSVEncoder_Encode(t *testing.T) {
+	encoded, err := CSVEncoder{}.Encode(testRecord())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "184.162.7.66,2026-07-25T12:00:00.000Z,ipify,150\n", string(encoded))
+}
+
+func TestJSONEncoder_Encode(t *testing.T) {
+	encoded, err := JSONEncoder{}.Encode(testRecord())
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ip":"184.162.7.66","timestamp":"2026-07-25T12:00:00.000Z","provider":"ipify","latency_ms":150}`, string(encoded))
+	assert.NotContains(t, string(encoded), "\n")
+}
+
+func TestJSONEncoder_EncodeNDJSONAppendsNewline(t *testing.T) {
+	encoded, err := JSONEncoder{NDJSON: true}.Encode(testRecord())
+
+	assert.NoError(t, err)
+	assert.True(t, len(encoded) > 0 && encoded[len(encoded)-1] == '\n')
+}
+
+func TestPrometheusEncoder_Encode(t *testing.T) {
+	encoded, err := PrometheusEncoder{}.Encode(testRecord())
+
+	assert.NoError(t, err)
+	assert.Equal(t, `public_ip_info{ip="184.162.7.66",provider="ipify"} 1`+"\n", string(encoded))
+}
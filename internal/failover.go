@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// FailoverGetter tries each Provider in order, returning the first
+// successful result and only failing once every provider has errored out.
+type FailoverGetter struct {
+	Providers []Provider
+	Mode      IPMode
+
+	mu       sync.Mutex
+	lastUsed string
+}
+
+// NewFailoverGetter builds a FailoverGetter that resolves ip addresses
+// matching mode, walking providers in the order given until one succeeds.
+func NewFailoverGetter(mode IPMode, providers ...Provider) *FailoverGetter {
+	return &FailoverGetter{Providers: providers, Mode: mode}
+}
+
+// GetPublicIP satisfies the main.IPGetter interface.
+func (f *FailoverGetter) GetPublicIP() (net.IP, error) {
+	var errs []string
+	for _, provider := range f.Providers {
+		ip, err := provider.GetPublicIP(f.Mode)
+		if err == nil {
+			f.mu.Lock()
+			f.lastUsed = provider.Name()
+			f.mu.Unlock()
+			return ip, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", provider.Name(), err))
+	}
+	return nil, fmt.Errorf("all providers failed: %s", strings.Join(errs, "; "))
+}
+
+// Name returns the provider that served the last successful GetPublicIP
+// call, satisfying the main.IPGetter interface.
+func (f *FailoverGetter) Name() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastUsed
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProvider is a Provider stand-in controlled by tests, avoiding network
+// calls when exercising FailoverGetter ordering.
+type fakeProvider struct {
+	name string
+	ip   net.IP
+	err  error
+}
+
+func (f *fakeProvider) Name() string { return f.name }
+
+func (f *fakeProvider) GetPublicIP(IPMode) (net.IP, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.ip, nil
+}
+
+func TestFailoverGetter_ReturnsFirstSuccess(t *testing.T) {
+	first := &fakeProvider{name: "first", err: fmt.Errorf("down")}
+	second := &fakeProvider{name: "second", ip: net.ParseIP("184.162.7.66")}
+	third := &fakeProvider{name: "third", ip: net.ParseIP("1.2.3.4")}
+
+	getter := NewFailoverGetter(IPModeAny, first, second, third)
+
+	ip, err := getter.GetPublicIP()
+
+	assert.NoError(t, err)
+	assert.Equal(t, second.ip, ip)
+	assert.Equal(t, "second", getter.Name())
+}
+
+func TestFailoverGetter_AllProvidersFail(t *testing.T) {
+	first := &fakeProvider{name: "first", err: fmt.Errorf("boom")}
+	second := &fakeProvider{name: "second", err: fmt.Errorf("bang")}
+
+	getter := NewFailoverGetter(IPModeAny, first, second)
+
+	ip, err := getter.GetPublicIP()
+
+	assert.Nil(t, ip)
+	assert.ErrorContains(t, err, "first: boom")
+	assert.ErrorContains(t, err, "second: bang")
+}
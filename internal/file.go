@@ -1,12 +1,112 @@
 package internal
 
 import (
+	"fmt"
 	"io/fs"
-	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
 )
 
-type FileRepository struct {}
+// FileRepository is the filesystem-backed main.FileWriter implementation.
+//
+// Write is atomic: the new content lands in a temp file in the same
+// directory, gets fsync'd, then is renamed over the destination so readers
+// never observe a partially written file.
+type FileRepository struct {
+	// MaxRotations keeps up to this many previous versions around as
+	// filename.1, filename.2, ... before each Write. Zero disables rotation.
+	MaxRotations int
+}
+
+// NewFileRepository builds a FileRepository keeping maxRotations previous
+// versions of each written file (0 disables rotation).
+func NewFileRepository(maxRotations int) *FileRepository {
+	return &FileRepository{MaxRotations: maxRotations}
+}
 
 func (f *FileRepository) Write(filename string, data []byte, perm fs.FileMode) error {
-	return ioutil.WriteFile(filename, data, perm)
-}
\ No newline at end of file
+	if f.MaxRotations > 0 {
+		if err := f.rotate(filename); err != nil {
+			return err
+		}
+	}
+	return atomicWrite(filename, data, perm)
+}
+
+// AppendWithTimestamp appends a single timestamped line to filename,
+// creating it with perm if it does not exist yet. Handy for keeping an IP
+// history log alongside the latest-value file.
+func (f *FileRepository) AppendWithTimestamp(filename string, data []byte, perm fs.FileMode) error {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s %s\n", time.Now().UTC().Format(time.RFC3339), data)
+	return err
+}
+
+// rotate shifts filename.1 -> filename.2, ..., dropping anything beyond
+// MaxRotations, then moves the current filename to filename.1.
+func (f *FileRepository) rotate(filename string) error {
+	if _, err := os.Stat(filename); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := os.Remove(rotatedName(filename, f.MaxRotations)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for i := f.MaxRotations - 1; i >= 1; i-- {
+		src := rotatedName(filename, i)
+		if _, err := os.Stat(src); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.Rename(src, rotatedName(filename, i+1)); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(filename, rotatedName(filename, 1))
+}
+
+func rotatedName(filename string, n int) string {
+	return fmt.Sprintf("%s.%d", filename, n)
+}
+
+// atomicWrite writes data to a temp file next to filename, fsyncs it, then
+// renames it over filename.
+func atomicWrite(filename string, data []byte, perm fs.FileMode) error {
+	dir := filepath.Dir(filename)
+	tmp, err := os.CreateTemp(dir, filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, filename)
+}
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRepository_WriteIsAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "output.txt")
+
+	repo := NewFileRepository(0)
+
+	assert.NoError(t, repo.Write(path, []byte("184.162.7.66"), 0644))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "184.162.7.66", string(content))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "no leftover temp file should remain")
+}
+
+func TestFileRepository_WriteWithoutRotationOverwrites(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "output.txt")
+
+	repo := NewFileRepository(0)
+
+	assert.NoError(t, repo.Write(path, []byte("first"), 0644))
+	assert.NoError(t, repo.Write(path, []byte("second"), 0644))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(content))
+
+	_, err = os.Stat(path + ".1")
+	assert.True(t, os.IsNotExist(err), "rotation disabled, no .1 file expected")
+}
+
+func TestFileRepository_WriteRotatesPreviousVersions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "output.txt")
+
+	repo := NewFileRepository(2)
+
+	assert.NoError(t, repo.Write(path, []byte("v1"), 0644))
+	assert.NoError(t, repo.Write(path, []byte("v2"), 0644))
+	assert.NoError(t, repo.Write(path, []byte("v3"), 0644))
+
+	current, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "v3", string(current))
+
+	rotated1, err := os.ReadFile(path + ".1")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", string(rotated1))
+
+	rotated2, err := os.ReadFile(path + ".2")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", string(rotated2))
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "rotation beyond MaxRotations should be dropped")
+}
+
+func TestFileRepository_AppendWithTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history.log")
+
+	repo := NewFileRepository(0)
+
+	assert.NoError(t, repo.AppendWithTimestamp(path, []byte("184.162.7.66"), 0644))
+	assert.NoError(t, repo.AppendWithTimestamp(path, []byte("1.2.3.4"), 0644))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "184.162.7.66")
+	assert.Contains(t, string(content), "1.2.3.4")
+	assert.Len(t, strings.Split(strings.TrimRight(string(content), "\n"), "\n"), 2)
+}
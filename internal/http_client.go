@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the request timeout used when a ClientConfig does not
+// override it.
+const DefaultTimeout = 5 * time.Second
+
+// DefaultMaxBodyBytes caps how much of a response body providers will read.
+// Public IP endpoints only ever return a few bytes, so anything beyond that
+// is treated as a malicious or misbehaving endpoint and discarded.
+const DefaultMaxBodyBytes = int64(100)
+
+// ClientConfig configures the HTTP client shared by the public IP providers.
+type ClientConfig struct {
+	Timeout      time.Duration
+	MaxBodyBytes int64
+}
+
+// NewClientConfig returns a ClientConfig falling back to the package
+// defaults for any zero value.
+func NewClientConfig(timeout time.Duration, maxBodyBytes int64) ClientConfig {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+	return ClientConfig{Timeout: timeout, MaxBodyBytes: maxBodyBytes}
+}
+
+func (c ClientConfig) httpClient() *http.Client {
+	return &http.Client{Timeout: c.Timeout}
+}
+
+// get performs a GET request and returns its body, capped at MaxBodyBytes.
+func (c ClientConfig) get(url string) ([]byte, error) {
+	resp, err := c.httpClient().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(io.LimitReader(resp.Body, c.MaxBodyBytes))
+}
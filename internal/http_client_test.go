@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientConfig_GetCapsBodyAtMaxBodyBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 500)))
+	}))
+	defer server.Close()
+
+	client := NewClientConfig(0, 10)
+
+	body, err := client.get(server.URL)
+
+	assert.NoError(t, err)
+	assert.Len(t, body, 10)
+}
+
+func TestNewClientConfig_DefaultsZeroValues(t *testing.T) {
+	client := NewClientConfig(0, 0)
+
+	assert.Equal(t, DefaultTimeout, client.Timeout)
+	assert.Equal(t, DefaultMaxBodyBytes, client.MaxBodyBytes)
+}
@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IcanHazIP resolves the public IP via icanhazip.com, which answers with a
+// plain-text IP rather than JSON.
+type IcanHazIP struct {
+	Client ClientConfig
+
+	// URLAny, URLv4 and URLv6 are the endpoints queried for each IPMode,
+	// overridable in tests to point at a stub server.
+	URLAny string
+	URLv4  string
+	URLv6  string
+}
+
+// NewIcanHazIP builds an IcanHazIP provider using client to talk to the API.
+func NewIcanHazIP(client ClientConfig) *IcanHazIP {
+	return &IcanHazIP{
+		Client: client,
+		URLAny: "https://icanhazip.com",
+		URLv4:  "https://ipv4.icanhazip.com",
+		URLv6:  "https://ipv6.icanhazip.com",
+	}
+}
+
+func (i *IcanHazIP) Name() string {
+	return "icanhazip"
+}
+
+func (i *IcanHazIP) GetPublicIP(mode IPMode) (net.IP, error) {
+	url := i.URLAny
+	switch mode {
+	case IPModeV4:
+		url = i.URLv4
+	case IPModeV6:
+		url = i.URLv6
+	}
+
+	body, err := i.Client.get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, fmt.Errorf("icanhazip: could not parse IP %q", string(body))
+	}
+	if !mode.Matches(ip) {
+		return nil, fmt.Errorf("icanhazip: resolved IP %q does not match requested mode", ip)
+	}
+	return ip, nil
+}
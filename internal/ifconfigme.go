@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IfConfigMe resolves the public IP via ifconfig.me, which answers with a
+// plain-text IP rather than JSON.
+type IfConfigMe struct {
+	Client ClientConfig
+
+	// URL is the endpoint queried, overridable in tests to point at a stub
+	// server.
+	URL string
+}
+
+// NewIfConfigMe builds an IfConfigMe provider using client to talk to the API.
+func NewIfConfigMe(client ClientConfig) *IfConfigMe {
+	return &IfConfigMe{Client: client, URL: "https://ifconfig.me/ip"}
+}
+
+func (i *IfConfigMe) Name() string {
+	return "ifconfig.me"
+}
+
+func (i *IfConfigMe) GetPublicIP(mode IPMode) (net.IP, error) {
+	body, err := i.Client.get(i.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, fmt.Errorf("ifconfig.me: could not parse IP %q", string(body))
+	}
+	if !mode.Matches(ip) {
+		return nil, fmt.Errorf("ifconfig.me: resolved IP %q does not match requested mode", ip)
+	}
+	return ip, nil
+}
@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfConfigMe_MalformedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not-an-ip\n"))
+	}))
+	defer server.Close()
+
+	provider := NewIfConfigMe(NewClientConfig(0, 0))
+	provider.URL = server.URL
+
+	ip, err := provider.GetPublicIP(IPModeAny)
+
+	assert.Nil(t, ip)
+	assert.ErrorContains(t, err, "could not parse IP")
+}
+
+func TestIfConfigMe_ModeMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("2001:db8::1\n"))
+	}))
+	defer server.Close()
+
+	provider := NewIfConfigMe(NewClientConfig(0, 0))
+	provider.URL = server.URL
+
+	ip, err := provider.GetPublicIP(IPModeV4)
+
+	assert.Nil(t, ip)
+	assert.ErrorContains(t, err, "does not match requested mode")
+}
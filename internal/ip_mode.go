@@ -0,0 +1,27 @@
+package internal
+
+import "net"
+
+// IPMode selects which IP family a Provider should resolve to.
+type IPMode int
+
+const (
+	// IPModeAny accepts whichever family the provider returns first.
+	IPModeAny IPMode = iota
+	// IPModeV4 requires an IPv4 address, discarding anything else.
+	IPModeV4
+	// IPModeV6 requires an IPv6 address, discarding anything else.
+	IPModeV6
+)
+
+// Matches reports whether ip satisfies the requested mode.
+func (m IPMode) Matches(ip net.IP) bool {
+	switch m {
+	case IPModeV4:
+		return ip.To4() != nil
+	case IPModeV6:
+		return ip.To4() == nil && ip.To16() != nil
+	default:
+		return ip != nil
+	}
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPMode_Matches(t *testing.T) {
+	v4 := net.ParseIP("184.162.7.66")
+	v6 := net.ParseIP("2001:db8::1")
+
+	tests := []struct {
+		name string
+		mode IPMode
+		ip   net.IP
+		want bool
+	}{
+		{"any matches v4", IPModeAny, v4, true},
+		{"any matches v6", IPModeAny, v6, true},
+		{"any rejects nil", IPModeAny, nil, false},
+		{"v4 matches v4", IPModeV4, v4, true},
+		{"v4 rejects v6", IPModeV4, v6, false},
+		{"v6 matches v6", IPModeV6, v6, true},
+		{"v6 rejects v4", IPModeV6, v4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.mode.Matches(tt.ip))
+		})
+	}
+}
@@ -2,21 +2,45 @@ package internal
 
 import (
 	"encoding/json"
-	"io"
-	"net/http"
+	"fmt"
+	"net"
 )
 
-type Ipify struct {}
+// Ipify resolves the public IP via the ipify.org API.
+type Ipify struct {
+	Client ClientConfig
 
-func (i *Ipify) GetPublicIP() (*string, error) {
-	resp, err := http.Get("https://api.ipify.org?format=json")
+	// URLAny, URLv4 and URLv6 are the endpoints queried for each IPMode,
+	// overridable in tests to point at a stub server.
+	URLAny string
+	URLv4  string
+	URLv6  string
+}
 
-	if err != nil {
-		return nil, err
+// NewIpify builds an Ipify provider using client to talk to the API.
+func NewIpify(client ClientConfig) *Ipify {
+	return &Ipify{
+		Client: client,
+		URLAny: "https://api64.ipify.org?format=json",
+		URLv4:  "https://api.ipify.org?format=json",
+		URLv6:  "https://api6.ipify.org?format=json",
 	}
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (i *Ipify) Name() string {
+	return "ipify"
+}
+
+func (i *Ipify) GetPublicIP(mode IPMode) (net.IP, error) {
+	url := i.URLAny
+	switch mode {
+	case IPModeV4:
+		url = i.URLv4
+	case IPModeV6:
+		url = i.URLv6
+	}
 
+	body, err := i.Client.get(url)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +50,13 @@ func (i *Ipify) GetPublicIP() (*string, error) {
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, err
 	}
-	ip := result["ip"]
-	return &ip, nil
-}
\ No newline at end of file
+
+	ip := net.ParseIP(result["ip"])
+	if ip == nil {
+		return nil, fmt.Errorf("ipify: could not parse IP %q", result["ip"])
+	}
+	if !mode.Matches(ip) {
+		return nil, fmt.Errorf("ipify: resolved IP %q does not match requested mode", ip)
+	}
+	return ip, nil
+}
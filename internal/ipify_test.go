@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIpify_MalformedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer server.Close()
+
+	provider := NewIpify(NewClientConfig(0, 0))
+	provider.URLAny = server.URL
+
+	ip, err := provider.GetPublicIP(IPModeAny)
+
+	assert.Nil(t, ip)
+	assert.Error(t, err)
+}
+
+func TestIpify_ModeMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ip":"2001:db8::1"}`))
+	}))
+	defer server.Close()
+
+	provider := NewIpify(NewClientConfig(0, 0))
+	provider.URLv4 = server.URL
+
+	ip, err := provider.GetPublicIP(IPModeV4)
+
+	assert.Nil(t, ip)
+	assert.ErrorContains(t, err, "does not match requested mode")
+}
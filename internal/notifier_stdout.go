@@ -0,0 +1,14 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+)
+
+// StdoutNotifier prints IP changes to standard output.
+type StdoutNotifier struct{}
+
+func (n *StdoutNotifier) Notify(ip net.IP) error {
+	_, err := fmt.Printf("public IP changed: %s\n", ip.String())
+	return err
+}
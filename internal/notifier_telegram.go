@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// telegramAPI is the base URL for the Telegram bot API.
+const telegramAPI = "https://api.telegram.org"
+
+// TelegramNotifier sends a message through a Telegram bot whenever the
+// public IP changes.
+type TelegramNotifier struct {
+	BotToken string
+	ChatID   string
+	Client   *http.Client
+
+	// BaseURL is the Telegram bot API root, overridable in tests to point at
+	// a stub server.
+	BaseURL string
+}
+
+// NewTelegramNotifier builds a TelegramNotifier for the given bot and chat.
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		BotToken: botToken,
+		ChatID:   chatID,
+		Client:   &http.Client{Timeout: DefaultTimeout},
+		BaseURL:  telegramAPI,
+	}
+}
+
+func (n *TelegramNotifier) Notify(ip net.IP) error {
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", n.BaseURL, n.BotToken)
+	params := url.Values{
+		"chat_id": {n.ChatID},
+		"text":    {fmt.Sprintf("public IP changed: %s", ip.String())},
+	}
+
+	resp, err := n.Client.PostForm(endpoint, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram notifier: unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdoutNotifier_Notify(t *testing.T) {
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	origStdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = origStdout }()
+
+	n := &StdoutNotifier{}
+
+	err = n.Notify(net.ParseIP("184.162.7.66"))
+	assert.NoError(t, err)
+
+	w.Close()
+	out, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "public IP changed: 184.162.7.66\n", string(out))
+}
+
+func TestWebhookNotifier_NotifySuccess(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL)
+
+	err := n.Notify(net.ParseIP("184.162.7.66"))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ip":"184.162.7.66"}`, string(body))
+}
+
+func TestWebhookNotifier_NotifyErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL)
+
+	err := n.Notify(net.ParseIP("184.162.7.66"))
+
+	assert.ErrorContains(t, err, "unexpected status")
+}
+
+func TestTelegramNotifier_NotifySuccess(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := NewTelegramNotifier("token", "chat-id")
+	n.BaseURL = server.URL
+
+	err := n.Notify(net.ParseIP("184.162.7.66"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/bottoken/sendMessage", path)
+}
+
+func TestTelegramNotifier_NotifyErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	n := NewTelegramNotifier("token", "chat-id")
+	n.BaseURL = server.URL
+
+	err := n.Notify(net.ParseIP("184.162.7.66"))
+
+	assert.ErrorContains(t, err, "unexpected status")
+}
@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// WebhookNotifier POSTs a JSON payload to a configured URL whenever the
+// public IP changes.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookNotifier builds a WebhookNotifier posting to url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Client: &http.Client{Timeout: DefaultTimeout}}
+}
+
+func (n *WebhookNotifier) Notify(ip net.IP) error {
+	payload, err := json.Marshal(map[string]string{"ip": ip.String()})
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.Client.Post(n.URL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook notifier: unexpected status %s", resp.Status)
+	}
+	return nil
+}
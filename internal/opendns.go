@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// openDNSResolver is the nameserver that answers the myip.opendns.com query.
+const openDNSResolver = "resolver1.opendns.com:53"
+
+// OpenDNS resolves the public IP via a DNS lookup against OpenDNS's
+// resolver, rather than an HTTP request.
+type OpenDNS struct {
+	Resolver net.Resolver
+	Timeout  time.Duration
+
+	// lookup performs the actual query, defaulting to Resolver.LookupIP.
+	// Overridable in tests to avoid a real DNS lookup.
+	lookup func(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// NewOpenDNS builds an OpenDNS provider that dials resolver1.opendns.com,
+// bounding the lookup by client.Timeout like the HTTP-based providers.
+func NewOpenDNS(client ClientConfig) *OpenDNS {
+	timeout := client.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	o := &OpenDNS{
+		Resolver: net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, openDNSResolver)
+			},
+		},
+		Timeout: timeout,
+	}
+	o.lookup = o.Resolver.LookupIP
+	return o
+}
+
+func (o *OpenDNS) Name() string {
+	return "opendns"
+}
+
+func (o *OpenDNS) GetPublicIP(mode IPMode) (net.IP, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+
+	ips, err := o.lookup(ctx, "ip", "myip.opendns.com")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ip := range ips {
+		if mode.Matches(ip) {
+			return ip, nil
+		}
+	}
+	return nil, fmt.Errorf("opendns: no IP matching the requested mode")
+}
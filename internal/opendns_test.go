@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenDNS_LookupError(t *testing.T) {
+	provider := NewOpenDNS(NewClientConfig(0, 0))
+	provider.lookup = func(ctx context.Context, network, host string) ([]net.IP, error) {
+		return nil, fmt.Errorf("no such host")
+	}
+
+	ip, err := provider.GetPublicIP(IPModeAny)
+
+	assert.Nil(t, ip)
+	assert.ErrorContains(t, err, "no such host")
+}
+
+func TestOpenDNS_ModeMismatch(t *testing.T) {
+	provider := NewOpenDNS(NewClientConfig(0, 0))
+	provider.lookup = func(ctx context.Context, network, host string) ([]net.IP, error) {
+		return []net.IP{net.ParseIP("2001:db8::1")}, nil
+	}
+
+	ip, err := provider.GetPublicIP(IPModeV4)
+
+	assert.Nil(t, ip)
+	assert.ErrorContains(t, err, "no IP matching the requested mode")
+}
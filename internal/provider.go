@@ -0,0 +1,11 @@
+package internal
+
+import "net"
+
+// Provider knows how to fetch the public IP from a single upstream source.
+type Provider interface {
+	// Name identifies the provider, mostly for logging and failover errors.
+	Name() string
+	// GetPublicIP resolves the caller's public IP, restricted to mode.
+	GetPublicIP(mode IPMode) (net.IP, error)
+}
@@ -0,0 +1,15 @@
+package internal
+
+import (
+	"net"
+	"time"
+)
+
+// Record is the structured result of a single public-IP resolution, carried
+// from an IPGetter down to an Encoder before it reaches a FileWriter.
+type Record struct {
+	IP        net.IP
+	Timestamp time.Time
+	Provider  string
+	Latency   time.Duration
+}
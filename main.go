@@ -1,38 +1,163 @@
 package main
 
 import (
+	"context"
 	"github.com/knlambert/unit-tests-demo/internal"
 	"io/fs"
 	"log"
-	"os"
+	"net"
+	"time"
 )
 
+//go:generate mockgen -source=main.go -destination=mock_main.go -package=main
+
 type IPGetter interface {
-	GetPublicIP() (*string, error)
+	GetPublicIP() (net.IP, error)
+	// Name identifies which upstream resolved the last GetPublicIP call, for
+	// the Encoder to record alongside the IP.
+	Name() string
 }
 
 type FileWriter interface {
 	Write(filename string, data []byte, perm fs.FileMode) error
+	AppendWithTimestamp(filename string, data []byte, perm fs.FileMode) error
+}
+
+// Notifier dispatches a message whenever the watched public IP changes.
+type Notifier interface {
+	Notify(ip net.IP) error
+}
+
+// Clock is the time source used by Watch, mockable so tests don't sleep for
+// real. After mirrors time.After: the returned channel fires once d has
+// elapsed, and Watch always races it against ctx.Done() so waits remain
+// promptly cancellable.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+// Encoder turns a resolved Record into the bytes handed to a FileWriter.
+type Encoder interface {
+	Encode(record internal.Record) ([]byte, error)
 }
 
 func Execute(
 	ipGetter IPGetter,
 	fileWriter FileWriter,
+	encoder Encoder,
 	outputFile string,
 ) error {
 	//Get request on the API.
+	start := time.Now()
 	publicIP, err := ipGetter.GetPublicIP()
 
 	if err != nil {
 		return err
 	}
 
+	record := internal.Record{
+		IP:        publicIP,
+		Timestamp: start,
+		Provider:  ipGetter.Name(),
+		Latency:   time.Since(start),
+	}
+
+	encoded, err := encoder.Encode(record)
+	if err != nil {
+		return err
+	}
+
 	//Write its content to a file.
-	return fileWriter.Write(outputFile, []byte(*publicIP), 0644)
+	return fileWriter.Write(outputFile, encoded, 0644)
+}
+
+// maxBackoff caps how long Watch will wait between retries after repeated
+// provider failures.
+const maxBackoff = 5 * time.Minute
+
+// Watch polls ipGetter every interval, encodes and persists the IP via
+// fileWriter, and notifies notifier only when the IP has actually changed.
+// When historyFile is non-empty, every change is also appended to it as a
+// timestamped line. It backs off exponentially (capped at maxBackoff) while
+// the provider errors out, and returns when ctx is done. A notifier failure
+// is logged and does not stop the loop, since watch is meant to run
+// unattended.
+func Watch(
+	ctx context.Context,
+	ipGetter IPGetter,
+	fileWriter FileWriter,
+	encoder Encoder,
+	notifier Notifier,
+	outputFile string,
+	historyFile string,
+	interval time.Duration,
+	clock Clock,
+) error {
+	var lastIP net.IP
+	backoff := interval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		start := time.Now()
+		publicIP, err := ipGetter.GetPublicIP()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-clock.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		backoff = interval
+
+		if lastIP == nil || !publicIP.Equal(lastIP) {
+			record := internal.Record{
+				IP:        publicIP,
+				Timestamp: start,
+				Provider:  ipGetter.Name(),
+				Latency:   time.Since(start),
+			}
+
+			encoded, err := encoder.Encode(record)
+			if err != nil {
+				return err
+			}
+			if err := fileWriter.Write(outputFile, encoded, 0644); err != nil {
+				return err
+			}
+			if historyFile != "" {
+				if err := fileWriter.AppendWithTimestamp(historyFile, []byte(publicIP.String()), 0644); err != nil {
+					return err
+				}
+			}
+			if err := notifier.Notify(publicIP); err != nil {
+				// A notifier failure (e.g. a transient webhook/Telegram 5xx)
+				// shouldn't kill an otherwise-healthy long-running watch; log
+				// it and keep polling.
+				log.Printf("watch: notifier failed: %s", err)
+			}
+			lastIP = publicIP
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(interval):
+		}
+	}
 }
 
 func main() {
-	if err := Execute(&internal.Ipify{}, &internal.FileRepository{}, os.Args[1]); err != nil {
+	if err := newRootCmd().Execute(); err != nil {
 		log.Fatal(err)
 	}
 }
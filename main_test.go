@@ -1,36 +1,74 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"github.com/golang/mock/gomock"
+	"github.com/knlambert/unit-tests-demo/internal"
 	"github.com/stretchr/testify/assert"
 	"io/fs"
+	"net"
 	"testing"
+	"time"
 )
 
+// recordMatcher matches an internal.Record on its IP and Provider, ignoring
+// Timestamp and Latency which Execute stamps with real wall-clock values.
+type recordMatcher struct {
+	ip       net.IP
+	provider string
+}
+
+func (m recordMatcher) Matches(x interface{}) bool {
+	record, ok := x.(internal.Record)
+	if !ok {
+		return false
+	}
+	return record.IP.Equal(m.ip) && record.Provider == m.provider
+}
+
+func (m recordMatcher) String() string {
+	return fmt.Sprintf("record{ip: %s, provider: %s}", m.ip, m.provider)
+}
+
 func TestExecute(t *testing.T) {
 	// A Controller represents the top-level control of a mock ecosystem.
 	ctrl := gomock.NewController(t)
 	// Create the mocks.
 	mockIpGetter := NewMockIPGetter(ctrl)
 	mockFileWriter := NewMockFileWriter(ctrl)
+	mockEncoder := NewMockEncoder(ctrl)
 
 	expectedOutputFile := "output.txt"
-	expectedIP := "184.162.7.66"
+	expectedIP := net.ParseIP("184.162.7.66")
+	expectedProvider := "ipify"
+	encoded := []byte(`{"ip":"184.162.7.66"}`)
 
 	// I expect GetPublicIp to return the IP above.
 	mockIpGetter.EXPECT().
 		GetPublicIP().
-		Return(&expectedIP, nil).
+		Return(expectedIP, nil).
+		Times(1)
+
+	mockIpGetter.EXPECT().
+		Name().
+		Return(expectedProvider).
+		Times(1)
+
+	// I expect the resulting Record to be handed to the configured Encoder.
+	mockEncoder.EXPECT().
+		Encode(recordMatcher{ip: expectedIP, provider: expectedProvider}).
+		Return(encoded, nil).
 		Times(1)
 
-	// I expect this ip to be written in the file output.txt.
+	// I expect the encoded bytes to be written in the file output.txt.
 	mockFileWriter.EXPECT().
-		Write(expectedOutputFile, []byte(expectedIP), fs.FileMode(0644)).
+		Write(expectedOutputFile, encoded, fs.FileMode(0644)).
 		Return(nil).
 		Times(1)
 
 	// Run the code.
-	err := Execute(mockIpGetter, mockFileWriter, expectedOutputFile)
+	err := Execute(mockIpGetter, mockFileWriter, mockEncoder, expectedOutputFile)
 
 	// Ensure there are no errors for this scenario.
 	assert.NoError(t, err, "no errors expected")
@@ -38,3 +76,176 @@ func TestExecute(t *testing.T) {
 	//Ensure that all the EXPECTed mocks have been called.
 	ctrl.Finish()
 }
+
+func TestWatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIpGetter := NewMockIPGetter(ctrl)
+	mockFileWriter := NewMockFileWriter(ctrl)
+	mockEncoder := NewMockEncoder(ctrl)
+	mockNotifier := NewMockNotifier(ctrl)
+	mockClock := NewMockClock(ctrl)
+
+	expectedOutputFile := "output.txt"
+	expectedProvider := "ipify"
+	firstIP := net.ParseIP("184.162.7.66")
+	encoded := []byte(`{"ip":"184.162.7.66"}`)
+	interval := time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The first poll finds a new IP, so it gets encoded, persisted and
+	// notified.
+	mockIpGetter.EXPECT().
+		GetPublicIP().
+		Return(firstIP, nil).
+		Times(2)
+
+	mockIpGetter.EXPECT().
+		Name().
+		Return(expectedProvider).
+		Times(1)
+
+	mockEncoder.EXPECT().
+		Encode(recordMatcher{ip: firstIP, provider: expectedProvider}).
+		Return(encoded, nil).
+		Times(1)
+
+	mockFileWriter.EXPECT().
+		Write(expectedOutputFile, encoded, fs.FileMode(0644)).
+		Return(nil).
+		Times(1)
+
+	mockNotifier.EXPECT().
+		Notify(firstIP).
+		Return(nil).
+		Times(1)
+
+	// After the first notification, the second poll sees the same IP: no
+	// further encode/write/notification, and the loop is stopped via ctx.
+	readyCh := make(chan time.Time, 1)
+	readyCh <- time.Now()
+	gomock.InOrder(
+		mockClock.EXPECT().After(interval).Return((<-chan time.Time)(readyCh)).Times(1),
+		mockClock.EXPECT().After(interval).DoAndReturn(func(time.Duration) <-chan time.Time {
+			cancel()
+			return closedTimeCh()
+		}).Times(1),
+	)
+
+	err := Watch(ctx, mockIpGetter, mockFileWriter, mockEncoder, mockNotifier, expectedOutputFile, "", interval, mockClock)
+
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctrl.Finish()
+}
+
+func TestWatch_AppendsHistoryOnChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIpGetter := NewMockIPGetter(ctrl)
+	mockFileWriter := NewMockFileWriter(ctrl)
+	mockEncoder := NewMockEncoder(ctrl)
+	mockNotifier := NewMockNotifier(ctrl)
+	mockClock := NewMockClock(ctrl)
+
+	expectedOutputFile := "output.txt"
+	expectedHistoryFile := "history.log"
+	firstIP := net.ParseIP("184.162.7.66")
+	encoded := []byte(`{"ip":"184.162.7.66"}`)
+	interval := time.Minute
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockIpGetter.EXPECT().GetPublicIP().Return(firstIP, nil).Times(1)
+	mockIpGetter.EXPECT().Name().Return("ipify").Times(1)
+	mockEncoder.EXPECT().Encode(gomock.Any()).Return(encoded, nil).Times(1)
+	mockFileWriter.EXPECT().Write(expectedOutputFile, encoded, fs.FileMode(0644)).Return(nil).Times(1)
+	mockFileWriter.EXPECT().
+		AppendWithTimestamp(expectedHistoryFile, []byte(firstIP.String()), fs.FileMode(0644)).
+		Return(nil).
+		Times(1)
+	mockNotifier.EXPECT().Notify(firstIP).Return(nil).Times(1)
+	mockClock.EXPECT().After(interval).DoAndReturn(func(time.Duration) <-chan time.Time {
+		cancel()
+		return closedTimeCh()
+	}).Times(1)
+
+	err := Watch(ctx, mockIpGetter, mockFileWriter, mockEncoder, mockNotifier, expectedOutputFile, expectedHistoryFile, interval, mockClock)
+
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctrl.Finish()
+}
+
+// closedTimeCh returns an already-fired time.Time channel, standing in for
+// time.After in tests that don't care about the exact delay.
+func closedTimeCh() <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func TestWatch_BacksOffExponentiallyOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIpGetter := NewMockIPGetter(ctrl)
+	mockFileWriter := NewMockFileWriter(ctrl)
+	mockEncoder := NewMockEncoder(ctrl)
+	mockNotifier := NewMockNotifier(ctrl)
+	mockClock := NewMockClock(ctrl)
+
+	expectedOutputFile := "output.txt"
+	interval := time.Second
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Three consecutive failures, so the backoff passed to the clock should
+	// double each time: interval, 2*interval, 4*interval.
+	gomock.InOrder(
+		mockIpGetter.EXPECT().GetPublicIP().Return(nil, fmt.Errorf("boom")).Times(1),
+		mockClock.EXPECT().After(interval).Return(closedTimeCh()).Times(1),
+		mockIpGetter.EXPECT().GetPublicIP().Return(nil, fmt.Errorf("boom")).Times(1),
+		mockClock.EXPECT().After(2*interval).Return(closedTimeCh()).Times(1),
+		mockIpGetter.EXPECT().GetPublicIP().Return(nil, fmt.Errorf("boom")).Times(1),
+		mockClock.EXPECT().After(4*interval).DoAndReturn(func(time.Duration) <-chan time.Time {
+			cancel()
+			return closedTimeCh()
+		}).Times(1),
+	)
+
+	err := Watch(ctx, mockIpGetter, mockFileWriter, mockEncoder, mockNotifier, expectedOutputFile, "", interval, mockClock)
+
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctrl.Finish()
+}
+
+func TestWatch_ContinuesAfterNotifierError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIpGetter := NewMockIPGetter(ctrl)
+	mockFileWriter := NewMockFileWriter(ctrl)
+	mockEncoder := NewMockEncoder(ctrl)
+	mockNotifier := NewMockNotifier(ctrl)
+	mockClock := NewMockClock(ctrl)
+
+	expectedOutputFile := "output.txt"
+	firstIP := net.ParseIP("184.162.7.66")
+	encoded := []byte(`{"ip":"184.162.7.66"}`)
+	interval := time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The poll finds a new IP; the notifier fails, but that must not stop
+	// the loop, so the next wait still runs and is what cancels the loop.
+	mockIpGetter.EXPECT().GetPublicIP().Return(firstIP, nil).Times(1)
+	mockIpGetter.EXPECT().Name().Return("ipify").Times(1)
+	mockEncoder.EXPECT().Encode(gomock.Any()).Return(encoded, nil).Times(1)
+	mockFileWriter.EXPECT().Write(expectedOutputFile, encoded, fs.FileMode(0644)).Return(nil).Times(1)
+	mockNotifier.EXPECT().Notify(firstIP).Return(fmt.Errorf("webhook: 503")).Times(1)
+	mockClock.EXPECT().After(interval).DoAndReturn(func(time.Duration) <-chan time.Time {
+		cancel()
+		return closedTimeCh()
+	}).Times(1)
+
+	err := Watch(ctx, mockIpGetter, mockFileWriter, mockEncoder, mockNotifier, expectedOutputFile, "", interval, mockClock)
+
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctrl.Finish()
+}